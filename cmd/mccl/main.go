@@ -0,0 +1,44 @@
+// Command mccl is a minimal Minecraft launcher: it installs versions
+// (libraries, natives, assets) and runs them offline against a real
+// classpath and launch command.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"mccl/commands"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: mccl <install|install-modpack|run|list|profile> [flags]")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "install":
+		err = commands.Install(os.Args[2:])
+	case "install-modpack":
+		err = commands.InstallModpack(os.Args[2:])
+	case "run":
+		err = commands.Run(os.Args[2:])
+	case "list":
+		err = commands.List(os.Args[2:])
+	case "profile":
+		err = commands.Profile(os.Args[2:])
+	default:
+		usage()
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "mccl:", err)
+		os.Exit(1)
+	}
+}
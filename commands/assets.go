@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"mccl/internal/fetch"
+)
+
+// AssetIndexRef is the assetIndex field of a version JSON: a pointer to the
+// asset index document itself, not the assets.
+type AssetIndexRef struct {
+	Id        string `json:"id"`
+	Sha1      string `json:"sha1"`
+	Size      int    `json:"size"`
+	TotalSize int    `json:"totalSize"`
+	Url       string `json:"url"`
+}
+
+type AssetIndex struct {
+	Objects map[string]struct {
+		Hash string `json:"hash"`
+		Size int    `json:"size"`
+	} `json:"objects"`
+}
+
+const assetResourceBaseURL = "https://resources.download.minecraft.net"
+
+// downloadAssets fetches the asset index itself, then every object it
+// references, into the standard assets/indexes and assets/objects layout.
+func downloadAssets(mcdir string, ref AssetIndexRef, workers int) error {
+	indexPath := filepath.Join(mcdir, "assets", "indexes", ref.Id+".json")
+	if err := fetch.Verified(ref.Url, indexPath, ref.Sha1); err != nil {
+		return fmt.Errorf("asset index: %w", err)
+	}
+
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		return err
+	}
+	var index AssetIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return fmt.Errorf("parsing asset index %s: %w", ref.Id, err)
+	}
+
+	objectsDir := filepath.Join(mcdir, "assets", "objects")
+	jobs := make([]fetch.Job, 0, len(index.Objects))
+	for name, obj := range index.Objects {
+		if len(obj.Hash) < 2 {
+			continue
+		}
+		prefix := obj.Hash[:2]
+		jobs = append(jobs, fetch.Job{
+			URL:   fmt.Sprintf("%s/%s/%s", assetResourceBaseURL, prefix, obj.Hash),
+			Dest:  filepath.Join(objectsDir, prefix, obj.Hash),
+			Sha1:  obj.Hash,
+			Label: "asset " + name,
+		})
+	}
+
+	fmt.Printf("Downloading %d assets with %d workers...\n", len(jobs), workers)
+	return fetch.RunPool(jobs, workers)
+}
@@ -0,0 +1,134 @@
+// Package commands implements each mccl subcommand (install,
+// install-modpack, run, list, profile). It owns the shared version-manifest/version-JSON plumbing that
+// every subcommand needs.
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"mccl/internal/fetch"
+)
+
+const versionManifestURL = "https://launchermeta.mojang.com/mc/game/version_manifest.json"
+
+type VersionRef struct {
+	Id  string `json:"id"`
+	Url string `json:"url"`
+}
+
+type VersionManifest struct {
+	Latest struct {
+		Release  string `json:"release"`
+		Snapshot string `json:"snapshot"`
+	} `json:"latest"`
+	Versions []VersionRef `json:"versions"`
+}
+
+type VersionJSON struct {
+	Id        string `json:"id"`
+	Assets    string `json:"assets"`
+	Downloads struct {
+		Client struct {
+			Sha1 string `json:"sha1"`
+			Size int    `json:"size"`
+			Url  string `json:"url"`
+		} `json:"client"`
+	} `json:"downloads"`
+	AssetIndex AssetIndexRef `json:"assetIndex"`
+	Libraries  []Library     `json:"libraries"`
+
+	MainClass          string     `json:"mainClass"`
+	Arguments          *Arguments `json:"arguments,omitempty"`
+	MinecraftArguments string     `json:"minecraftArguments,omitempty"`
+	InheritsFrom       string     `json:"inheritsFrom,omitempty"`
+
+	JavaVersion struct {
+		Component    string `json:"component"`
+		MajorVersion int    `json:"majorVersion"`
+	} `json:"javaVersion,omitempty"`
+}
+
+func ensureDir(p string) error {
+	return os.MkdirAll(p, 0o755)
+}
+
+func defaultMCDir() string {
+	return filepath.Join(os.Getenv("HOME"), ".minecraft")
+}
+
+func fetchManifest() (VersionManifest, error) {
+	var manifest VersionManifest
+	err := fetch.JSON(versionManifestURL, &manifest)
+	return manifest, err
+}
+
+func findVersionURL(manifest *VersionManifest, id string) (string, error) {
+	for _, v := range manifest.Versions {
+		if v.Id == id {
+			return v.Url, nil
+		}
+	}
+	return "", errors.New("version not found")
+}
+
+// loadOrFetchVersionJSON returns the version JSON for id, preferring an
+// already-installed copy under <mcdir>/versions/<id>/<id>.json (the only
+// place a Forge/Fabric profile installed by a modloader, rather than
+// Mojang's manifest, can be found) and falling back to the manifest.
+func loadOrFetchVersionJSON(mcdir string, manifest *VersionManifest, id string) (VersionJSON, error) {
+	localPath := filepath.Join(mcdir, "versions", id, id+".json")
+	if data, err := os.ReadFile(localPath); err == nil {
+		var vjson VersionJSON
+		if err := json.Unmarshal(data, &vjson); err == nil {
+			return vjson, nil
+		}
+	}
+
+	vurl, err := findVersionURL(manifest, id)
+	if err != nil {
+		return VersionJSON{}, err
+	}
+
+	var vjson VersionJSON
+	if err := fetch.JSON(vurl, &vjson); err != nil {
+		return VersionJSON{}, err
+	}
+	return vjson, nil
+}
+
+// saveVersionJSON caches a fetched version JSON alongside its jar, so that
+// loadOrFetchVersionJSON later reads it from disk instead of fetching it
+// again.
+func saveVersionJSON(mcdir, id string, vjson VersionJSON) error {
+	dir := filepath.Join(mcdir, "versions", id)
+	if err := ensureDir(dir); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(vjson, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, id+".json"), data, 0o644)
+}
+
+// installedVersions lists the version IDs under <mcdir>/versions/.
+func installedVersions(mcdir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(mcdir, "versions"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, e := range entries {
+		if e.IsDir() {
+			ids = append(ids, e.Name())
+		}
+	}
+	return ids, nil
+}
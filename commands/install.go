@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"mccl/internal/fetch"
+	"mccl/internal/profile"
+)
+
+// Install implements `mccl install`: it fetches the version manifest, then
+// downloads the client jar, every allowed library and native, and the full
+// asset set for the requested version.
+func Install(args []string) error {
+	fs := flag.NewFlagSet("install", flag.ExitOnError)
+	version := fs.String("version", "", "Minecraft version ID to install (e.g. 1.20.2). If empty, uses latest release.")
+	mcdir := fs.String("mcdir", defaultMCDir(), "Minecraft game directory")
+	workers := fs.Int("j", 8, "number of parallel downloads for libraries and assets")
+	fs.Parse(args)
+
+	fmt.Println("Fetching version manifest...")
+	manifest, err := fetchManifest()
+	if err != nil {
+		return fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+
+	vid := *version
+	if vid == "" {
+		vid = manifest.Latest.Release
+		fmt.Println("No version specified; using latest release:", vid)
+	}
+
+	vurl, err := findVersionURL(&manifest, vid)
+	if err != nil {
+		return fmt.Errorf("version not found in manifest: %s", vid)
+	}
+
+	if err := installVersion(*mcdir, vid, vurl, *workers); err != nil {
+		return err
+	}
+
+	prof, err := profile.Load(*mcdir)
+	if err != nil {
+		return fmt.Errorf("failed to load profile: %w", err)
+	}
+	prof.VersionID = vid
+	if err := profile.Save(*mcdir, prof); err != nil {
+		return fmt.Errorf("failed to save profile: %w", err)
+	}
+
+	return nil
+}
+
+// installVersion downloads the client jar, libraries, natives and assets for
+// a single version JSON reachable at vurl. It is the shared core behind both
+// `mccl install` and the modpack installer's vanilla step.
+func installVersion(mcdir, vid, vurl string, workers int) error {
+	fmt.Println("Fetching version JSON for", vid)
+	var vjson VersionJSON
+	if err := fetch.JSON(vurl, &vjson); err != nil {
+		return fmt.Errorf("failed to fetch version json: %w", err)
+	}
+
+	versionDir := filepath.Join(mcdir, "versions", vid)
+	if err := ensureDir(versionDir); err != nil {
+		return fmt.Errorf("failed to create version dir: %w", err)
+	}
+
+	jarPath := filepath.Join(versionDir, vid+".jar")
+	fmt.Println("Downloading client jar to", jarPath)
+	if err := fetch.Verified(vjson.Downloads.Client.Url, jarPath, vjson.Downloads.Client.Sha1); err != nil {
+		return fmt.Errorf("failed to download client jar: %w", err)
+	}
+
+	fmt.Println("Resolving libraries...")
+	resolved := resolveLibraries(vjson.Libraries)
+
+	var libJobs []fetch.Job
+	for _, rl := range resolved {
+		if rl.artifact != nil && rl.artifact.Url != "" {
+			libJobs = append(libJobs, fetch.Job{
+				URL:   rl.artifact.Url,
+				Dest:  filepath.Join(mcdir, "libraries", filepath.FromSlash(rl.artifact.Path)),
+				Sha1:  rl.artifact.Sha1,
+				Label: "library " + rl.lib.Name,
+			})
+		}
+		if rl.nativeArt != nil && rl.nativeArt.Url != "" {
+			libJobs = append(libJobs, fetch.Job{
+				URL:   rl.nativeArt.Url,
+				Dest:  filepath.Join(mcdir, "libraries", filepath.FromSlash(rl.nativeArt.Path)),
+				Sha1:  rl.nativeArt.Sha1,
+				Label: "natives " + rl.lib.Name,
+			})
+		}
+	}
+
+	fmt.Printf("Downloading %d libraries with %d workers...\n", len(libJobs), workers)
+	if err := fetch.RunPool(libJobs, workers); err != nil {
+		return fmt.Errorf("failed to download libraries: %w", err)
+	}
+
+	nativesDir := filepath.Join(versionDir, "natives")
+	for _, rl := range resolved {
+		if rl.nativeArt == nil {
+			continue
+		}
+		jar := filepath.Join(mcdir, "libraries", filepath.FromSlash(rl.nativeArt.Path))
+		if err := extractNatives(jar, nativesDir, rl.excludes); err != nil {
+			fmt.Println("warning: failed to extract natives from", rl.lib.Name+":", err)
+		}
+	}
+
+	if err := downloadAssets(mcdir, vjson.AssetIndex, workers); err != nil {
+		return fmt.Errorf("failed to download assets: %w", err)
+	}
+
+	if err := saveVersionJSON(mcdir, vid, vjson); err != nil {
+		return fmt.Errorf("failed to cache version json: %w", err)
+	}
+
+	fmt.Println("Done. Version installed to", versionDir)
+	return nil
+}
+
+// installVersionByID resolves vid against the manifest and installs it; used
+// when the caller (e.g. the modpack installer) only has a version ID, not
+// its manifest URL, in hand.
+func installVersionByID(mcdir, vid string, workers int) error {
+	manifest, err := fetchManifest()
+	if err != nil {
+		return fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+	vurl, err := findVersionURL(&manifest, vid)
+	if err != nil {
+		return fmt.Errorf("version not found in manifest: %s", vid)
+	}
+	return installVersion(mcdir, vid, vurl, workers)
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"mccl/internal/modpack"
+	"mccl/internal/profile"
+)
+
+// InstallModpack implements `mccl install-modpack <path-or-url>`: it ingests
+// a Modrinth .mrpack or a CurseForge pack (zip or standalone manifest.json)
+// and installs the resulting instance.
+func InstallModpack(args []string) error {
+	fs := flag.NewFlagSet("install-modpack", flag.ExitOnError)
+	mcdir := fs.String("mcdir", defaultMCDir(), "Minecraft game directory")
+	workers := fs.Int("j", 8, "number of parallel downloads")
+	curseForgeAPIKey := fs.String("curseforge-api-key", os.Getenv("CURSEFORGE_API_KEY"), "CurseForge API key, required for CurseForge packs")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: mccl install-modpack [flags] <path-or-url>")
+	}
+	source := fs.Arg(0)
+
+	result, err := modpack.Install(source, *mcdir, *workers, *curseForgeAPIKey, func(versionID string) error {
+		return installVersionByID(*mcdir, versionID, *workers)
+	})
+	if err != nil {
+		return err
+	}
+
+	prof, err := profile.Load(*mcdir)
+	if err != nil {
+		return fmt.Errorf("failed to load profile: %w", err)
+	}
+	if result.ModLoader != "" {
+		fmt.Println("Installed", result.Name, "-", result.MinecraftVersion, "with", result.ModLoader)
+	} else {
+		fmt.Println("Installed", result.Name, "-", result.MinecraftVersion)
+	}
+	if result.ProfileVersionID != "" {
+		prof.VersionID = result.ProfileVersionID
+		fmt.Println("Profile set to", result.ProfileVersionID+"; run `mccl run` to launch it.")
+	}
+	if err := profile.Save(*mcdir, prof); err != nil {
+		return fmt.Errorf("failed to save profile: %w", err)
+	}
+
+	fmt.Printf("%d mod files installed.\n", result.FilesInstalled)
+	return nil
+}
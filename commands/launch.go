@@ -0,0 +1,213 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Arguments holds the modern (1.13+) arguments.jvm / arguments.game arrays.
+// Pre-1.13 versions instead use the legacy MinecraftArguments string.
+type Arguments struct {
+	JVM  []ArgumentEntry `json:"jvm"`
+	Game []ArgumentEntry `json:"game"`
+}
+
+// ArgumentEntry is either a bare string or a {rules, value} object where
+// value is a string or an array of strings.
+type ArgumentEntry struct {
+	Rules  []Rule
+	Values []string
+}
+
+func (a *ArgumentEntry) UnmarshalJSON(data []byte) error {
+	var plain string
+	if err := json.Unmarshal(data, &plain); err == nil {
+		a.Values = []string{plain}
+		return nil
+	}
+
+	var obj struct {
+		Rules []Rule          `json:"rules"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	a.Rules = obj.Rules
+
+	var single string
+	if err := json.Unmarshal(obj.Value, &single); err == nil {
+		a.Values = []string{single}
+		return nil
+	}
+
+	var multi []string
+	if err := json.Unmarshal(obj.Value, &multi); err != nil {
+		return fmt.Errorf("argument value is neither string nor []string: %w", err)
+	}
+	a.Values = multi
+	return nil
+}
+
+// ResolvedVersion is a version manifest with any inheritsFrom chain already
+// merged in: libraries and arguments are the union of parent then child,
+// mainClass and minecraftArguments take the child's value when set.
+type ResolvedVersion struct {
+	Id                 string
+	MainClass          string
+	Libraries          []Library
+	Arguments          *Arguments
+	MinecraftArguments string
+	AssetIndex         AssetIndexRef
+	JavaComponent      string
+	JavaMajorVersion   int
+}
+
+// resolveVersionChain follows inheritsFrom (used by Forge/Fabric profiles)
+// back to its vanilla base and merges each level on top of its parent.
+func resolveVersionChain(id string, fetchVersionJSON func(id string) (VersionJSON, error)) (ResolvedVersion, error) {
+	vjson, err := fetchVersionJSON(id)
+	if err != nil {
+		return ResolvedVersion{}, err
+	}
+
+	rv := ResolvedVersion{
+		Id:                 id,
+		MainClass:          vjson.MainClass,
+		Libraries:          vjson.Libraries,
+		Arguments:          vjson.Arguments,
+		MinecraftArguments: vjson.MinecraftArguments,
+		AssetIndex:         vjson.AssetIndex,
+		JavaComponent:      vjson.JavaVersion.Component,
+		JavaMajorVersion:   vjson.JavaVersion.MajorVersion,
+	}
+
+	if vjson.InheritsFrom == "" {
+		return rv, nil
+	}
+
+	parent, err := resolveVersionChain(vjson.InheritsFrom, fetchVersionJSON)
+	if err != nil {
+		return ResolvedVersion{}, fmt.Errorf("resolving parent version %s: %w", vjson.InheritsFrom, err)
+	}
+
+	merged := parent
+	merged.Id = rv.Id
+	merged.Libraries = append(append([]Library{}, parent.Libraries...), rv.Libraries...)
+
+	if rv.MainClass != "" {
+		merged.MainClass = rv.MainClass
+	}
+	if rv.MinecraftArguments != "" {
+		merged.MinecraftArguments = rv.MinecraftArguments
+	}
+	if rv.AssetIndex.Id != "" {
+		merged.AssetIndex = rv.AssetIndex
+	}
+	if rv.JavaComponent != "" {
+		merged.JavaComponent = rv.JavaComponent
+		merged.JavaMajorVersion = rv.JavaMajorVersion
+	}
+	switch {
+	case rv.Arguments == nil:
+		// nothing to add
+	case merged.Arguments == nil:
+		merged.Arguments = rv.Arguments
+	default:
+		merged.Arguments = &Arguments{
+			JVM:  append(append([]ArgumentEntry{}, merged.Arguments.JVM...), rv.Arguments.JVM...),
+			Game: append(append([]ArgumentEntry{}, merged.Arguments.Game...), rv.Arguments.Game...),
+		}
+	}
+	return merged, nil
+}
+
+func classpathSeparator() string {
+	if runtime.GOOS == "windows" {
+		return ";"
+	}
+	return ":"
+}
+
+// buildClasspath joins every allowed library's main jar with the client jar,
+// using the OS-correct separator.
+func buildClasspath(mcdir string, libs []Library, clientJar string) string {
+	resolved := resolveLibraries(libs)
+	entries := make([]string, 0, len(resolved)+1)
+	for _, rl := range resolved {
+		if rl.artifact == nil {
+			continue // natives-only entries never go on the classpath
+		}
+		entries = append(entries, filepath.Join(mcdir, "libraries", filepath.FromSlash(rl.artifact.Path)))
+	}
+	entries = append(entries, clientJar)
+	return strings.Join(entries, classpathSeparator())
+}
+
+type launchPlaceholders map[string]string
+
+func substitutePlaceholders(arg string, ph launchPlaceholders) string {
+	for key, val := range ph {
+		arg = strings.ReplaceAll(arg, "${"+key+"}", val)
+	}
+	return arg
+}
+
+func expandArguments(entries []ArgumentEntry, ph launchPlaceholders) []string {
+	var out []string
+	for _, e := range entries {
+		if !evaluateRules(e.Rules) {
+			continue
+		}
+		for _, v := range e.Values {
+			out = append(out, substitutePlaceholders(v, ph))
+		}
+	}
+	return out
+}
+
+// legacyGameArgs splits the pre-1.13 space-separated minecraftArguments
+// string and substitutes placeholders the same way the modern array form
+// does.
+func legacyGameArgs(raw string, ph launchPlaceholders) []string {
+	fields := strings.Fields(raw)
+	out := make([]string, len(fields))
+	for i, f := range fields {
+		out[i] = substitutePlaceholders(f, ph)
+	}
+	return out
+}
+
+// buildLaunchCommand assembles the full `java ...` invocation for rv,
+// substituting ph into every JVM and game argument. extraJVMArgs (e.g.
+// -Xmx/-Xms) are inserted ahead of the version's own JVM arguments.
+func buildLaunchCommand(javaBin string, extraJVMArgs []string, rv ResolvedVersion, mcdir, clientJar string, ph launchPlaceholders) []string {
+	ph["classpath"] = buildClasspath(mcdir, rv.Libraries, clientJar)
+
+	cmd := []string{javaBin}
+	cmd = append(cmd, extraJVMArgs...)
+
+	if rv.Arguments != nil {
+		cmd = append(cmd, expandArguments(rv.Arguments.JVM, ph)...)
+	} else {
+		// Pre-1.13 versions predate arguments.jvm; reproduce the minimum
+		// the vanilla launcher has always passed in that case.
+		cmd = append(cmd,
+			"-Djava.library.path="+ph["natives_directory"],
+			"-cp", ph["classpath"],
+		)
+	}
+
+	cmd = append(cmd, rv.MainClass)
+
+	if rv.Arguments != nil {
+		cmd = append(cmd, expandArguments(rv.Arguments.Game, ph)...)
+	} else {
+		cmd = append(cmd, legacyGameArgs(rv.MinecraftArguments, ph)...)
+	}
+
+	return cmd
+}
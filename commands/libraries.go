@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"archive/zip"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"mccl/internal/fetch"
+)
+
+// Artifact is a single downloadable file entry as found under a library's
+// downloads.artifact or downloads.classifiers map.
+type Artifact struct {
+	Path string `json:"path"`
+	Sha1 string `json:"sha1"`
+	Size int    `json:"size"`
+	Url  string `json:"url"`
+}
+
+type Library struct {
+	Name      string `json:"name"`
+	Rules     []Rule `json:"rules,omitempty"`
+	Downloads struct {
+		Artifact    *Artifact           `json:"artifact,omitempty"`
+		Classifiers map[string]Artifact `json:"classifiers,omitempty"`
+	} `json:"downloads"`
+	Natives map[string]string `json:"natives,omitempty"`
+	Extract *struct {
+		Exclude []string `json:"exclude"`
+	} `json:"extract,omitempty"`
+}
+
+// resolvedLibrary is a Library that has already passed its rule check, paired
+// with the artifacts (main jar and/or natives jar) that apply on this OS.
+type resolvedLibrary struct {
+	lib       Library
+	artifact  *Artifact
+	nativeArt *Artifact
+	excludes  []string
+}
+
+func mojangOSName() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "osx"
+	case "windows":
+		return "windows"
+	default:
+		return "linux"
+	}
+}
+
+func mojangArchBits() string {
+	if strings.Contains(runtime.GOARCH, "64") {
+		return "64"
+	}
+	return "32"
+}
+
+// mojangArch translates runtime.GOARCH into the vocabulary Mojang's version
+// manifests use for os.arch rules ("x86", "x86_64", "arm64", ...).
+func mojangArch() string {
+	switch runtime.GOARCH {
+	case "amd64":
+		return "x86_64"
+	case "386":
+		return "x86"
+	case "arm64":
+		return "arm64"
+	case "arm":
+		return "arm"
+	default:
+		return runtime.GOARCH
+	}
+}
+
+// runtimeOSVersion is used against a rule's os.version regex. Go does not
+// expose the running kernel/OS version directly, so we leave it blank; a
+// blank string only fails a rule that explicitly pins a version, which is
+// rare in practice (Mojang mostly uses it to exclude old macOS releases).
+func runtimeOSVersion() string {
+	return ""
+}
+
+// resolveLibraries filters libs down to the ones whose rules allow the
+// current platform and attaches whichever artifacts (main jar, natives jar)
+// apply here.
+func resolveLibraries(libs []Library) []resolvedLibrary {
+	osName := mojangOSName()
+	var out []resolvedLibrary
+	for _, lib := range libs {
+		if !evaluateRules(lib.Rules) {
+			continue
+		}
+
+		rl := resolvedLibrary{lib: lib}
+		if lib.Downloads.Artifact != nil {
+			rl.artifact = lib.Downloads.Artifact
+		}
+
+		if classifierKey := lib.Natives[osName]; classifierKey != "" {
+			classifierKey = strings.ReplaceAll(classifierKey, "${arch}", mojangArchBits())
+			if art, ok := lib.Downloads.Classifiers[classifierKey]; ok {
+				artCopy := art
+				rl.nativeArt = &artCopy
+			}
+		}
+
+		if lib.Extract != nil {
+			rl.excludes = lib.Extract.Exclude
+		}
+
+		if rl.artifact == nil && rl.nativeArt == nil {
+			continue
+		}
+		out = append(out, rl)
+	}
+	return out
+}
+
+// extractNatives unpacks a natives jar into destDir, skipping META-INF and
+// anything covered by the library's extract.exclude list.
+func extractNatives(jarPath, destDir string, excludes []string) error {
+	r, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := ensureDir(destDir); err != nil {
+		return err
+	}
+
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if strings.HasPrefix(f.Name, "META-INF/") || nativeExcluded(f.Name, excludes) {
+			continue
+		}
+		target, err := safeJoin(destDir, f.Name)
+		if err != nil {
+			return fmt.Errorf("natives jar entry %q: %w", f.Name, err)
+		}
+		if err := fetch.ZipEntry(f, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// safeJoin joins name onto destDir and rejects the result if it would land
+// outside destDir (a "zip-slip" entry like "../../etc/passwd").
+func safeJoin(destDir, name string) (string, error) {
+	target := filepath.Join(destDir, filepath.FromSlash(name))
+	destWithSep := filepath.Clean(destDir) + string(filepath.Separator)
+	if !strings.HasPrefix(target, destWithSep) {
+		return "", fmt.Errorf("escapes destination directory %q", destDir)
+	}
+	return target, nil
+}
+
+func nativeExcluded(name string, excludes []string) bool {
+	for _, ex := range excludes {
+		if strings.HasPrefix(name, ex) {
+			return true
+		}
+	}
+	return false
+}
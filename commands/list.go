@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+)
+
+// List implements `mccl list`: it enumerates the versions installed under
+// <mcdir>/versions/.
+func List(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	mcdir := fs.String("mcdir", defaultMCDir(), "Minecraft game directory")
+	fs.Parse(args)
+
+	ids, err := installedVersions(*mcdir)
+	if err != nil {
+		return fmt.Errorf("failed to list versions: %w", err)
+	}
+
+	if len(ids) == 0 {
+		fmt.Println("No versions installed under", *mcdir)
+		return nil
+	}
+
+	for _, id := range ids {
+		fmt.Println(id)
+	}
+	return nil
+}
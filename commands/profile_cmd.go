@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+
+	"mccl/internal/profile"
+)
+
+// Profile implements `mccl profile set|get`.
+func Profile(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: mccl profile <set|get> [flags]")
+	}
+
+	switch args[0] {
+	case "get":
+		return profileGet(args[1:])
+	case "set":
+		return profileSet(args[1:])
+	default:
+		return fmt.Errorf("unknown profile subcommand %q (want set or get)", args[0])
+	}
+}
+
+func profileGet(args []string) error {
+	fs := flag.NewFlagSet("profile get", flag.ExitOnError)
+	mcdir := fs.String("mcdir", defaultMCDir(), "Minecraft game directory")
+	fs.Parse(args)
+
+	prof, err := profile.Load(*mcdir)
+	if err != nil {
+		return fmt.Errorf("failed to load profile: %w", err)
+	}
+
+	fmt.Printf("username:  %s\n", prof.Username)
+	fmt.Printf("uuid:      %s\n", prof.UUID)
+	fmt.Printf("version:   %s\n", prof.VersionID)
+	fmt.Printf("xmx:       %d\n", prof.MemMaxMB)
+	fmt.Printf("xms:       %d\n", prof.MemMinMB)
+	fmt.Printf("java path: %s\n", prof.JavaPath)
+	return nil
+}
+
+func profileSet(args []string) error {
+	fs := flag.NewFlagSet("profile set", flag.ExitOnError)
+	mcdir := fs.String("mcdir", defaultMCDir(), "Minecraft game directory")
+	username := fs.String("username", "", "offline username")
+	version := fs.String("version", "", "default version ID")
+	xmx := fs.Int("xmx", 0, "max heap size in MB")
+	xms := fs.Int("xms", 0, "min heap size in MB")
+	javaPath := fs.String("java", "", "path to a java binary to use")
+	fs.Parse(args)
+
+	prof, err := profile.Load(*mcdir)
+	if err != nil {
+		return fmt.Errorf("failed to load profile: %w", err)
+	}
+
+	if *username != "" {
+		prof.Username = *username
+		prof.UUID = offlineUUID(*username)
+	}
+	if *version != "" {
+		prof.VersionID = *version
+	}
+	if *xmx != 0 {
+		prof.MemMaxMB = *xmx
+	}
+	if *xms != 0 {
+		prof.MemMinMB = *xms
+	}
+	if *javaPath != "" {
+		prof.JavaPath = *javaPath
+	}
+
+	return profile.Save(*mcdir, prof)
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"regexp"
+)
+
+// Rule mirrors the os/feature gated entries found throughout a version
+// manifest: library downloads, JVM arguments and game arguments all use the
+// same allow/disallow shape.
+type Rule struct {
+	Action   string          `json:"action"`
+	OS       *RuleOS         `json:"os,omitempty"`
+	Features map[string]bool `json:"features,omitempty"`
+}
+
+type RuleOS struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+	Arch    string `json:"arch,omitempty"`
+}
+
+// evaluateRules applies Mojang's rule semantics: absent rules mean "always
+// allowed", otherwise the last matching rule decides the outcome.
+func evaluateRules(rules []Rule) bool {
+	allowed := len(rules) == 0
+	for _, r := range rules {
+		if !ruleMatches(r) {
+			continue
+		}
+		allowed = r.Action == "allow"
+	}
+	return allowed
+}
+
+func ruleMatches(r Rule) bool {
+	if r.OS != nil && !osRuleMatches(*r.OS) {
+		return false
+	}
+	// Feature-gated rules (e.g. demo user, custom resolution) never apply to
+	// a plain launch, so treat any non-empty feature set as non-matching.
+	if len(r.Features) > 0 {
+		return false
+	}
+	return true
+}
+
+func osRuleMatches(os RuleOS) bool {
+	if os.Name != "" && os.Name != mojangOSName() {
+		return false
+	}
+	if os.Arch != "" && os.Arch != mojangArch() {
+		return false
+	}
+	if os.Version != "" {
+		matched, err := regexp.MatchString(os.Version, runtimeOSVersion())
+		if err != nil || !matched {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,191 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"mccl/internal/auth"
+	"mccl/internal/javart"
+	"mccl/internal/profile"
+)
+
+const (
+	defaultUsername = "Player"
+	defaultMemMB    = 2048
+
+	// defaultMSAClientID is the Microsoft Azure app ID community launchers
+	// commonly use for device-code sign-in; register your own app (Azure
+	// Portal -> App registrations) for production use and pass -client-id.
+	defaultMSAClientID = "00000000402b5328"
+)
+
+// Run implements `mccl run`: it resolves the version's full inheritsFrom
+// chain, its JRE, builds the real launch command and execs it. Any flag left
+// unset falls back to the persisted profile; whatever ends up being used is
+// saved back to the profile for next time.
+func Run(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	mcdir := fs.String("mcdir", defaultMCDir(), "Minecraft game directory")
+	version := fs.String("version", "", "version ID to run (defaults to the profile's last-used version)")
+	username := fs.String("username", "", "offline username (defaults to the profile's last-used username)")
+	xmx := fs.Int("xmx", 0, "max heap size in MB (defaults to the profile's last-used value, or 2048)")
+	xms := fs.Int("xms", 0, "min heap size in MB (defaults to the profile's last-used value, or xmx's value)")
+	useSystemJava := fs.Bool("use-system-java", false, "use a JRE already installed on this machine instead of downloading Mojang's own")
+	online := fs.Bool("online", false, "sign in with a Microsoft account instead of launching offline")
+	clientID := fs.String("client-id", defaultMSAClientID, "Microsoft Azure app client ID to use for -online sign-in")
+	fs.Parse(args)
+
+	prof, err := profile.Load(*mcdir)
+	if err != nil {
+		return fmt.Errorf("failed to load profile: %w", err)
+	}
+
+	vid := firstNonEmpty(*version, prof.VersionID)
+	if vid == "" {
+		return fmt.Errorf("no version specified and no version in profile; run `mccl install -version <id>` first")
+	}
+
+	name := firstNonEmpty(*username, prof.Username, defaultUsername)
+
+	maxMB := *xmx
+	if maxMB == 0 {
+		maxMB = prof.MemMaxMB
+	}
+	if maxMB == 0 {
+		maxMB = defaultMemMB
+	}
+	minMB := *xms
+	if minMB == 0 {
+		minMB = prof.MemMinMB
+	}
+	if minMB == 0 {
+		minMB = maxMB
+	}
+
+	manifest, err := fetchManifest()
+	if err != nil {
+		return fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+
+	rv, err := resolveVersionChain(vid, func(id string) (VersionJSON, error) {
+		return loadOrFetchVersionJSON(*mcdir, &manifest, id)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve version chain: %w", err)
+	}
+
+	jre, err := resolveJava(*mcdir, rv, prof, *useSystemJava)
+	if err != nil {
+		return fmt.Errorf("failed to resolve java runtime: %w", err)
+	}
+
+	versionDir := filepath.Join(*mcdir, "versions", vid)
+	jarPath := filepath.Join(versionDir, vid+".jar")
+
+	ph := launchPlaceholders{
+		"auth_player_name":  name,
+		"version_name":      vid,
+		"game_directory":    *mcdir,
+		"assets_root":       filepath.Join(*mcdir, "assets"),
+		"assets_index_name": rv.AssetIndex.Id,
+		"auth_uuid":         offlineUUID(name),
+		"auth_access_token": "0",
+		"user_type":         "legacy",
+		"version_type":      "release",
+		"natives_directory": filepath.Join(versionDir, "natives"),
+		"launcher_name":     "mccl",
+		"launcher_version":  "0.1",
+	}
+
+	if *online {
+		session, err := auth.Login(*mcdir, *clientID)
+		if err != nil {
+			return fmt.Errorf("microsoft sign-in failed: %w", err)
+		}
+		name = session.Username
+		ph["auth_player_name"] = session.Username
+		ph["auth_uuid"] = session.UUID
+		ph["auth_access_token"] = session.MinecraftAccessToken
+		ph["user_type"] = "msa"
+	}
+
+	extraJVM := []string{
+		fmt.Sprintf("-Xmx%dM", maxMB),
+		fmt.Sprintf("-Xms%dM", minMB),
+	}
+
+	cmdline := buildLaunchCommand(jre.JavaBin, extraJVM, rv, *mcdir, jarPath, ph)
+	logCmdline := cmdline
+	if *online {
+		logCmdline = redactSecrets(cmdline, ph["auth_access_token"])
+	}
+	fmt.Println("Launching:", strings.Join(logCmdline, " "))
+
+	prof.Username = name
+	prof.UUID = ph["auth_uuid"]
+	prof.VersionID = vid
+	prof.MemMaxMB = maxMB
+	prof.MemMinMB = minMB
+	prof.JavaPath = jre.JavaBin
+	if err := profile.Save(*mcdir, prof); err != nil {
+		return fmt.Errorf("failed to save profile: %w", err)
+	}
+
+	cmd := exec.Command(cmdline[0], cmdline[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// resolveJava prefers a JRE path already pinned in the profile, but only if
+// it actually satisfies the version being launched; otherwise it defers to
+// javart's system-scan/download resolution. Without this check, a cached
+// path from a previous (different-Java) version would get reused unchanged
+// and fail at launch with a cryptic UnsupportedClassVersionError.
+func resolveJava(mcdir string, rv ResolvedVersion, prof *profile.Profile, useSystemJava bool) (*javart.Runtime, error) {
+	if prof.JavaPath != "" {
+		if major, err := javart.JavaMajorVersion(prof.JavaPath); err == nil {
+			if rv.JavaMajorVersion == 0 || major == rv.JavaMajorVersion {
+				return &javart.Runtime{JavaBin: prof.JavaPath, MajorVersion: major}, nil
+			}
+		}
+	}
+
+	component := rv.JavaComponent
+	if component == "" {
+		component = "jre-legacy"
+	}
+	return javart.Resolve(mcdir, component, rv.JavaMajorVersion, useSystemJava)
+}
+
+// redactSecrets returns a copy of cmdline with any argument containing one
+// of secrets replaced wholesale, so a live auth token never reaches stdout,
+// shell history, or a CI log via the "Launching: ..." line.
+func redactSecrets(cmdline []string, secrets ...string) []string {
+	out := make([]string, len(cmdline))
+	copy(out, cmdline)
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		for i, arg := range out {
+			if strings.Contains(arg, secret) {
+				out[i] = "<redacted>"
+			}
+		}
+	}
+	return out
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,19 @@
+package commands
+
+import (
+	"crypto/md5"
+	"fmt"
+)
+
+// offlineUUID derives a deterministic, offline-mode UUID from a username the
+// same way the vanilla launcher does: a version-3 (name-based, MD5) UUID
+// over "OfflinePlayer:<name>".
+func offlineUUID(username string) string {
+	name := "OfflinePlayer:" + username
+	sum := md5.Sum([]byte(name))
+
+	sum[6] = (sum[6] & 0x0f) | 0x30 // version 3
+	sum[8] = (sum[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
@@ -0,0 +1,321 @@
+// Package auth implements Microsoft's OAuth2 device-code flow and the
+// Xbox Live / Minecraft services token exchange needed for online play:
+// Microsoft token -> XBL token -> XSTS token -> Minecraft access token.
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	deviceCodeURL = "https://login.microsoftonline.com/consumers/oauth2/v2.0/devicecode"
+	tokenURL      = "https://login.microsoftonline.com/consumers/oauth2/v2.0/token"
+	xblAuthURL    = "https://user.auth.xboxlive.com/user/authenticate"
+	xstsAuthURL   = "https://xsts.auth.xboxlive.com/xsts/authorize"
+	mcLoginURL    = "https://api.minecraftservices.com/authentication/login_with_xbox"
+	mcProfileURL  = "https://api.minecraftservices.com/minecraft/profile"
+
+	oauthScope = "XboxLive.signin offline_access"
+)
+
+// Session is everything the launch path needs to pass --accessToken/--uuid
+// for an online session.
+type Session struct {
+	MinecraftAccessToken string    `json:"minecraftAccessToken"`
+	MinecraftExpiresAt   time.Time `json:"minecraftExpiresAt"`
+	UUID                 string    `json:"uuid"`
+	Username             string    `json:"username"`
+
+	// MicrosoftRefreshToken is kept so a future run can silently refresh
+	// instead of re-running the device-code flow.
+	MicrosoftRefreshToken string `json:"microsoftRefreshToken"`
+}
+
+func (s *Session) expired() bool {
+	return s == nil || s.MinecraftAccessToken == "" || time.Now().After(s.MinecraftExpiresAt)
+}
+
+// DeviceCode is what the user is told to type into verificationURI.
+type DeviceCode struct {
+	DeviceCode      string
+	UserCode        string
+	VerificationURI string
+	Interval        time.Duration
+	ExpiresAt       time.Time
+}
+
+type deviceCodeResponse struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+type msTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Error        string `json:"error"`
+}
+
+// StartDeviceCode requests a user_code/verification_uri pair for clientID.
+func StartDeviceCode(clientID string) (*DeviceCode, error) {
+	form := url.Values{
+		"client_id": {clientID},
+		"scope":     {oauthScope},
+	}
+
+	var resp deviceCodeResponse
+	if err := postForm(deviceCodeURL, form, &resp); err != nil {
+		return nil, fmt.Errorf("requesting device code: %w", err)
+	}
+
+	return &DeviceCode{
+		DeviceCode:      resp.DeviceCode,
+		UserCode:        resp.UserCode,
+		VerificationURI: resp.VerificationURI,
+		Interval:        time.Duration(resp.Interval) * time.Second,
+		ExpiresAt:       time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// PollDeviceCode polls the token endpoint on dc.Interval until the user
+// approves the sign-in, the code expires, or an unrecoverable error occurs.
+func PollDeviceCode(clientID string, dc *DeviceCode) (accessToken, refreshToken string, err error) {
+	form := url.Values{
+		"client_id":   {clientID},
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		"device_code": {dc.DeviceCode},
+	}
+
+	for time.Now().Before(dc.ExpiresAt) {
+		time.Sleep(dc.Interval)
+
+		var resp msTokenResponse
+		if err := postForm(tokenURL, form, &resp); err != nil {
+			return "", "", err
+		}
+
+		switch resp.Error {
+		case "":
+			return resp.AccessToken, resp.RefreshToken, nil
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			dc.Interval += 5 * time.Second
+			continue
+		default:
+			return "", "", fmt.Errorf("microsoft sign-in failed: %s", resp.Error)
+		}
+	}
+	return "", "", fmt.Errorf("device code expired before sign-in was approved")
+}
+
+// RefreshMicrosoftToken exchanges a previously-issued refresh token for a
+// fresh Microsoft access token, without any user interaction.
+func RefreshMicrosoftToken(clientID, refreshToken string) (accessToken, newRefreshToken string, err error) {
+	form := url.Values{
+		"client_id":     {clientID},
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+		"scope":         {oauthScope},
+	}
+
+	var resp msTokenResponse
+	if err := postForm(tokenURL, form, &resp); err != nil {
+		return "", "", err
+	}
+	if resp.Error != "" {
+		return "", "", fmt.Errorf("refreshing microsoft token: %s", resp.Error)
+	}
+	return resp.AccessToken, resp.RefreshToken, nil
+}
+
+type xblAuthRequest struct {
+	Properties struct {
+		AuthMethod string `json:"AuthMethod"`
+		SiteName   string `json:"SiteName"`
+		RpsTicket  string `json:"RpsTicket"`
+	} `json:"Properties"`
+	RelyingParty string `json:"RelyingParty"`
+	TokenType    string `json:"TokenType"`
+}
+
+type xblAuthResponse struct {
+	Token         string `json:"Token"`
+	DisplayClaims struct {
+		Xui []struct {
+			Uhs string `json:"uhs"`
+		} `json:"xui"`
+	} `json:"DisplayClaims"`
+}
+
+// authenticateXBL exchanges a Microsoft access token for an Xbox Live (XBL)
+// token and the user hash ("uhs") that the XSTS step needs.
+func authenticateXBL(msAccessToken string) (xblToken, userHash string, err error) {
+	req := xblAuthRequest{RelyingParty: "http://auth.xboxlive.com", TokenType: "JWT"}
+	req.Properties.AuthMethod = "RPS"
+	req.Properties.SiteName = "user.auth.xboxlive.com"
+	req.Properties.RpsTicket = "d=" + msAccessToken
+
+	var resp xblAuthResponse
+	if err := postJSON(xblAuthURL, req, &resp); err != nil {
+		return "", "", fmt.Errorf("XBL authentication: %w", err)
+	}
+	if len(resp.DisplayClaims.Xui) == 0 {
+		return "", "", fmt.Errorf("XBL authentication: no user hash in response")
+	}
+	return resp.Token, resp.DisplayClaims.Xui[0].Uhs, nil
+}
+
+type xstsAuthRequest struct {
+	Properties struct {
+		SandboxId  string   `json:"SandboxId"`
+		UserTokens []string `json:"UserTokens"`
+	} `json:"Properties"`
+	RelyingParty string `json:"RelyingParty"`
+	TokenType    string `json:"TokenType"`
+}
+
+// authenticateXSTS exchanges an XBL token for the XSTS token Minecraft
+// services expects, carrying the same user hash through.
+func authenticateXSTS(xblToken string) (xstsToken, userHash string, err error) {
+	req := xstsAuthRequest{RelyingParty: "rp://api.minecraftservices.com/", TokenType: "JWT"}
+	req.Properties.SandboxId = "RETAIL"
+	req.Properties.UserTokens = []string{xblToken}
+
+	var resp xblAuthResponse
+	if err := postJSON(xstsAuthURL, req, &resp); err != nil {
+		return "", "", fmt.Errorf("XSTS authentication: %w", err)
+	}
+	if len(resp.DisplayClaims.Xui) == 0 {
+		return "", "", fmt.Errorf("XSTS authentication: no user hash in response")
+	}
+	return resp.Token, resp.DisplayClaims.Xui[0].Uhs, nil
+}
+
+type mcLoginRequest struct {
+	IdentityToken string `json:"identityToken"`
+}
+
+type mcLoginResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// loginWithXbox exchanges an XSTS token + user hash for a Minecraft access
+// token.
+func loginWithXbox(userHash, xstsToken string) (accessToken string, expiresIn int, err error) {
+	req := mcLoginRequest{IdentityToken: fmt.Sprintf("XBL3.0 x=%s;%s", userHash, xstsToken)}
+
+	var resp mcLoginResponse
+	if err := postJSON(mcLoginURL, req, &resp); err != nil {
+		return "", 0, fmt.Errorf("minecraft services login: %w", err)
+	}
+	if resp.AccessToken == "" {
+		return "", 0, fmt.Errorf("minecraft services login returned no access token")
+	}
+	return resp.AccessToken, resp.ExpiresIn, nil
+}
+
+type mcProfileResponse struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// fetchProfile fetches the UUID/username attached to a Minecraft access
+// token. A 404 here means the account owns no Minecraft license.
+func fetchProfile(accessToken string) (uuid, name string, err error) {
+	req, err := http.NewRequest(http.MethodGet, mcProfileURL, nil)
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return "", "", fmt.Errorf("this Microsoft account does not own Minecraft")
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("fetching profile: http %d", res.StatusCode)
+	}
+
+	var resp mcProfileResponse
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return "", "", err
+	}
+	return resp.Id, resp.Name, nil
+}
+
+// exchange walks a Microsoft access token all the way through to a
+// Minecraft Session (XBL -> XSTS -> Minecraft login -> profile).
+func exchange(msAccessToken, msRefreshToken string) (*Session, error) {
+	xblToken, uhs, err := authenticateXBL(msAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	xstsToken, uhs2, err := authenticateXSTS(xblToken)
+	if err != nil {
+		return nil, err
+	}
+	if uhs2 != "" {
+		uhs = uhs2
+	}
+
+	mcToken, expiresIn, err := loginWithXbox(uhs, xstsToken)
+	if err != nil {
+		return nil, err
+	}
+
+	uuid, name, err := fetchProfile(mcToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		MinecraftAccessToken:  mcToken,
+		MinecraftExpiresAt:    time.Now().Add(time.Duration(expiresIn) * time.Second),
+		UUID:                  uuid,
+		Username:              name,
+		MicrosoftRefreshToken: msRefreshToken,
+	}, nil
+}
+
+func postForm(endpoint string, form url.Values, v interface{}) error {
+	res, err := http.PostForm(endpoint, form)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+func postJSON(endpoint string, body, v interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	res, err := http.Post(endpoint, "application/json", strings.NewReader(string(data)))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		drained, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("http %d: %s", res.StatusCode, string(drained))
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
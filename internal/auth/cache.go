@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const cacheFileName = "mccl_auth.json"
+
+// Login returns a ready-to-use Session for clientID: a cached one if it's
+// still valid, a silently refreshed one if a cached refresh token still
+// works, or one obtained by running the interactive device-code flow and
+// printing the instructions the user needs to follow.
+func Login(mcdir, clientID string) (*Session, error) {
+	cached, err := loadCached(mcdir)
+	if err != nil {
+		return nil, fmt.Errorf("reading cached session: %w", err)
+	}
+
+	if !cached.expired() {
+		return cached, nil
+	}
+
+	if cached != nil && cached.MicrosoftRefreshToken != "" {
+		if msAccess, msRefresh, err := RefreshMicrosoftToken(clientID, cached.MicrosoftRefreshToken); err == nil {
+			if session, err := exchange(msAccess, msRefresh); err == nil {
+				return session, saveCached(mcdir, session)
+			}
+		}
+		// Refresh token no longer works; fall through to a fresh sign-in.
+	}
+
+	dc, err := StartDeviceCode(clientID)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("To sign in, open %s and enter the code: %s\n", dc.VerificationURI, dc.UserCode)
+
+	msAccess, msRefresh, err := PollDeviceCode(clientID, dc)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := exchange(msAccess, msRefresh)
+	if err != nil {
+		return nil, err
+	}
+	return session, saveCached(mcdir, session)
+}
+
+// loadCached and saveCached persist the session (including its Microsoft
+// refresh token) as plain 0600 JSON. There's no OS keychain/secret-store
+// integration here to bind a key to, so encrypting the file with a key
+// stored unprotected alongside it would add complexity without adding real
+// confidentiality; filesystem permissions are the only boundary this cache
+// relies on.
+func loadCached(mcdir string) (*Session, error) {
+	data, err := os.ReadFile(filepath.Join(mcdir, cacheFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		// A corrupt cache shouldn't block login; just treat it as absent and
+		// let the caller sign in again.
+		return nil, nil
+	}
+	return &session, nil
+}
+
+func saveCached(mcdir string, session *Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(mcdir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(mcdir, cacheFileName), data, 0o600)
+}
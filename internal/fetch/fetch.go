@@ -0,0 +1,200 @@
+// Package fetch holds the fetch/verify/zip-extract logic shared by every
+// subcommand and internal package that downloads files from Mojang,
+// Modrinth, CurseForge, or Fabric: JSON GETs, sha1-verified downloads (with
+// retry-on-mismatch), a bounded worker pool to run a batch of them, and the
+// matching zip-entry extraction helper.
+package fetch
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// maxRetries is how many attempts in total Verified makes at a download that
+// fails or comes back with the wrong sha1 before giving up.
+const maxRetries = 3
+
+// Job is one unit of work for RunPool: fetch URL to Dest, verifying Sha1 if
+// one is known.
+type Job struct {
+	URL   string
+	Dest  string
+	Sha1  string
+	Label string
+}
+
+// Sha1File hashes the file at path.
+func Sha1File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha1.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Verified fetches url to dest via a .partial file, retrying on failure or
+// sha1 mismatch. If dest already exists with a matching hash (or, when no
+// hash is known, simply exists) the download is skipped.
+func Verified(url, dest, expectedSha1 string) error {
+	if expectedSha1 != "" {
+		if existing, err := Sha1File(dest); err == nil && existing == expectedSha1 {
+			return nil
+		}
+	} else if _, err := os.Stat(dest); err == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return err
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		if err := fetchToFile(url, dest); err != nil {
+			lastErr = err
+			continue
+		}
+		if expectedSha1 == "" {
+			return nil
+		}
+		actual, err := Sha1File(dest)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if actual == expectedSha1 {
+			return nil
+		}
+		lastErr = fmt.Errorf("sha1 mismatch: got %s want %s", actual, expectedSha1)
+		os.Remove(dest)
+	}
+	return fmt.Errorf("%s: failed after %d attempts: %w", dest, maxRetries, lastErr)
+}
+
+func fetchToFile(url, dest string) error {
+	partial := dest + ".partial"
+	out, err := os.Create(partial)
+	if err != nil {
+		return err
+	}
+
+	res, err := (&http.Client{}).Get(url)
+	if err != nil {
+		out.Close()
+		os.Remove(partial)
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		out.Close()
+		os.Remove(partial)
+		return fmt.Errorf("http %d", res.StatusCode)
+	}
+
+	if _, err := io.Copy(out, res.Body); err != nil {
+		out.Close()
+		os.Remove(partial)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Rename(partial, dest)
+}
+
+// RunPool fans jobs out across a bounded pool of workers and reports a
+// combined error if any job ultimately failed.
+func RunPool(jobs []Job, workers int) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobCh := make(chan Job)
+	errCh := make(chan error, len(jobs))
+	done := make(chan struct{})
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range jobCh {
+				if err := Verified(job.URL, job.Dest, job.Sha1); err != nil {
+					errCh <- fmt.Errorf("%s: %w", job.Label, err)
+				}
+			}
+			done <- struct{}{}
+		}()
+	}
+
+	go func() {
+		for _, j := range jobs {
+			jobCh <- j
+		}
+		close(jobCh)
+	}()
+
+	for i := 0; i < workers; i++ {
+		<-done
+	}
+	close(errCh)
+
+	var firstErr error
+	failed := 0
+	for err := range errCh {
+		failed++
+		if firstErr == nil {
+			firstErr = err
+		}
+		fmt.Fprintln(os.Stderr, "download error:", err)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d downloads failed (first error: %w)", failed, len(jobs), firstErr)
+	}
+	return nil
+}
+
+// JSON GETs url and decodes its body into v.
+func JSON(url string, v interface{}) error {
+	res, err := (&http.Client{}).Get(url)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("http %d fetching %s", res.StatusCode, url)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// ZipEntry extracts f to target, creating target's parent directory first.
+func ZipEntry(f *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, rc)
+	return err
+}
@@ -0,0 +1,256 @@
+// Package javart resolves and, if necessary, downloads the JRE a given
+// Minecraft version asks for (via its javaVersion.component field) instead
+// of relying on whatever "java" happens to be on PATH.
+package javart
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+
+	"mccl/internal/fetch"
+)
+
+// runtimeIndexURL is Mojang's per-OS index of available JRE/JDK builds,
+// keyed by component name (e.g. "java-runtime-gamma", "jre-legacy").
+const runtimeIndexURL = "https://piston-meta.mojang.com/v1/products/java-runtime/2ec0cc96c44e5a76b9c8b7c39df7210883d12871/all.json"
+
+// Runtime describes a resolved JRE: a java binary ready to exec.
+type Runtime struct {
+	JavaBin      string
+	MajorVersion int
+}
+
+type runtimeIndexEntry struct {
+	Manifest struct {
+		Sha1 string `json:"sha1"`
+		Size int    `json:"size"`
+		Url  string `json:"url"`
+	} `json:"manifest"`
+	Version struct {
+		Name string `json:"name"`
+	} `json:"version"`
+}
+
+// runtimeIndex maps os-key -> component -> available builds (newest first).
+type runtimeIndex map[string]map[string][]runtimeIndexEntry
+
+type filesManifest struct {
+	Files map[string]fileEntry `json:"files"`
+}
+
+type fileEntry struct {
+	Type       string `json:"type"`
+	Executable bool   `json:"executable"`
+	Target     string `json:"target,omitempty"`
+	Downloads  struct {
+		Raw struct {
+			Sha1 string `json:"sha1"`
+			Size int    `json:"size"`
+			Url  string `json:"url"`
+		} `json:"raw"`
+	} `json:"downloads"`
+}
+
+// Resolve finds a JRE satisfying component/majorVersion. When useSystemJava
+// is true it first looks among system installs; otherwise, or when none
+// matches, it downloads one under <mcdir>/runtime/<component>/.
+func Resolve(mcdir, component string, majorVersion int, useSystemJava bool) (*Runtime, error) {
+	if useSystemJava {
+		if rt := findSystemJava(majorVersion); rt != nil {
+			return rt, nil
+		}
+	}
+	return downloadRuntime(mcdir, component)
+}
+
+// findSystemJava scans JAVA_HOME and the usual per-OS install locations for
+// a java binary whose reported version matches majorVersion.
+func findSystemJava(majorVersion int) *Runtime {
+	for _, candidate := range systemJavaCandidates() {
+		major, err := JavaMajorVersion(candidate)
+		if err != nil {
+			continue
+		}
+		if majorVersion == 0 || major == majorVersion {
+			return &Runtime{JavaBin: candidate, MajorVersion: major}
+		}
+	}
+	return nil
+}
+
+func systemJavaCandidates() []string {
+	var paths []string
+
+	if home := os.Getenv("JAVA_HOME"); home != "" {
+		paths = append(paths, filepath.Join(home, "bin", javaExeName()))
+	}
+
+	switch runtime.GOOS {
+	case "windows":
+		matches, _ := filepath.Glob(`C:\Program Files\Java\*\bin\java.exe`)
+		paths = append(paths, matches...)
+	case "darwin":
+		matches, _ := filepath.Glob("/Library/Java/JavaVirtualMachines/*/Contents/Home/bin/java")
+		paths = append(paths, matches...)
+	default:
+		matches, _ := filepath.Glob("/usr/lib/jvm/*/bin/java")
+		paths = append(paths, matches...)
+	}
+
+	if p, err := exec.LookPath("java"); err == nil {
+		paths = append(paths, p)
+	}
+
+	return paths
+}
+
+func javaExeName() string {
+	if runtime.GOOS == "windows" {
+		return "java.exe"
+	}
+	return "java"
+}
+
+var javaVersionPattern = regexp.MustCompile(`version "(\d+)(?:\.(\d+))?`)
+
+// JavaMajorVersion runs `java -version` and parses its major version,
+// handling both the old 1.8-style scheme and the modern 9+ scheme.
+func JavaMajorVersion(javaBin string) (int, error) {
+	if _, err := os.Stat(javaBin); err != nil {
+		return 0, err
+	}
+
+	cmd := exec.Command(javaBin, "-version")
+	out, _ := cmd.CombinedOutput() // java -version writes to stderr; exit may be 0 regardless
+
+	m := javaVersionPattern.FindStringSubmatch(string(out))
+	if m == nil {
+		return 0, fmt.Errorf("could not parse java version from %s", javaBin)
+	}
+
+	major, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, err
+	}
+	if major == 1 && m[2] != "" {
+		// "1.8.0_292" style: the real major version is the second group.
+		return strconv.Atoi(m[2])
+	}
+	return major, nil
+}
+
+// mojangRuntimeOS maps a Go GOOS/GOARCH pair to the key Mojang's runtime
+// index uses.
+func mojangRuntimeOS() (string, error) {
+	switch runtime.GOOS {
+	case "linux":
+		if runtime.GOARCH == "386" {
+			return "linux-i386", nil
+		}
+		return "linux", nil
+	case "windows":
+		if runtime.GOARCH == "386" {
+			return "windows-x86", nil
+		}
+		return "windows-x64", nil
+	case "darwin":
+		if runtime.GOARCH == "arm64" {
+			return "mac-os-arm64", nil
+		}
+		return "mac-os", nil
+	default:
+		return "", fmt.Errorf("unsupported OS for Java runtime download: %s", runtime.GOOS)
+	}
+}
+
+func downloadRuntime(mcdir, component string) (*Runtime, error) {
+	osKey, err := mojangRuntimeOS()
+	if err != nil {
+		return nil, err
+	}
+
+	var index runtimeIndex
+	if err := fetch.JSON(runtimeIndexURL, &index); err != nil {
+		return nil, fmt.Errorf("fetching java runtime index: %w", err)
+	}
+
+	builds, ok := index[osKey][component]
+	if !ok || len(builds) == 0 {
+		return nil, fmt.Errorf("no java runtime available for component %q on %s", component, osKey)
+	}
+	build := builds[0]
+
+	destDir := filepath.Join(mcdir, "runtime", component, osKey, component)
+	javaBin := filepath.Join(destDir, "bin", javaExeName())
+
+	if major, err := JavaMajorVersion(javaBin); err == nil {
+		return &Runtime{JavaBin: javaBin, MajorVersion: major}, nil
+	}
+
+	var files filesManifest
+	if err := fetch.JSON(build.Manifest.Url, &files); err != nil {
+		return nil, fmt.Errorf("fetching runtime files manifest: %w", err)
+	}
+
+	if err := installRuntimeFiles(destDir, files); err != nil {
+		return nil, fmt.Errorf("installing java runtime: %w", err)
+	}
+
+	major, err := JavaMajorVersion(javaBin)
+	if err != nil {
+		return nil, fmt.Errorf("downloaded runtime but could not run it: %w", err)
+	}
+	return &Runtime{JavaBin: javaBin, MajorVersion: major}, nil
+}
+
+// installRuntimeFiles lays out the runtime's directories, downloads its
+// files (verifying sha1), and reproduces symlinks, in that order so links
+// can target files that already exist.
+func installRuntimeFiles(destDir string, files filesManifest) error {
+	for name, entry := range files.Files {
+		if entry.Type == "directory" {
+			if err := os.MkdirAll(filepath.Join(destDir, filepath.FromSlash(name)), 0o755); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name, entry := range files.Files {
+		if entry.Type != "file" {
+			continue
+		}
+		target := filepath.Join(destDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := fetch.Verified(entry.Downloads.Raw.Url, target, entry.Downloads.Raw.Sha1); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if entry.Executable {
+			if err := os.Chmod(target, 0o755); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name, entry := range files.Files {
+		if entry.Type != "link" {
+			continue
+		}
+		link := filepath.Join(destDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(link), 0o755); err != nil {
+			return err
+		}
+		os.Remove(link)
+		if err := os.Symlink(entry.Target, link); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package modpack
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"mccl/internal/fetch"
+)
+
+type curseForgeManifest struct {
+	Minecraft struct {
+		Version    string `json:"version"`
+		ModLoaders []struct {
+			Id      string `json:"id"`
+			Primary bool   `json:"primary"`
+		} `json:"modLoaders"`
+	} `json:"minecraft"`
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Overrides string `json:"overrides"`
+	Files     []struct {
+		ProjectID int  `json:"projectID"`
+		FileID    int  `json:"fileID"`
+		Required  bool `json:"required"`
+	} `json:"files"`
+}
+
+const curseForgeAPIBase = "https://api.curseforge.com/v1"
+
+// InstallCurseForge installs a CurseForge modpack from a standalone
+// manifest.json file (overrides, if any, must already be laid down; use
+// Install on the pack's zip to also extract them).
+func InstallCurseForge(manifestPath, mcdir string, workers int, apiKey string, installVanilla InstallVanilla) (*Result, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	var manifest curseForgeManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	return installCurseForgeManifest(manifest, mcdir, workers, apiKey, installVanilla)
+}
+
+// installCurseForgeZip is Install's entry point when the CurseForge pack is
+// still a zip: it extracts overrides itself before delegating to the shared
+// manifest-driven install.
+func installCurseForgeZip(r *zip.ReadCloser, mcdir string, workers int, apiKey string, installVanilla InstallVanilla) (*Result, error) {
+	var manifest curseForgeManifest
+	if err := readZipJSON(r, "manifest.json", &manifest); err != nil {
+		return nil, fmt.Errorf("reading manifest.json: %w", err)
+	}
+
+	prefix := strings.TrimSuffix(manifest.Overrides, "/") + "/"
+	if err := extractOverrides(r, prefix, mcdir); err != nil {
+		return nil, fmt.Errorf("extracting overrides: %w", err)
+	}
+
+	return installCurseForgeManifest(manifest, mcdir, workers, apiKey, installVanilla)
+}
+
+func installCurseForgeManifest(manifest curseForgeManifest, mcdir string, workers int, apiKey string, installVanilla InstallVanilla) (*Result, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("a CurseForge API key is required to resolve mod download URLs (set -curseforge-api-key or CURSEFORGE_API_KEY)")
+	}
+
+	if err := ensureDir(filepath.Join(mcdir, "mods")); err != nil {
+		return nil, err
+	}
+
+	var jobs []fetch.Job
+	for _, f := range manifest.Files {
+		url, fileName, err := curseForgeDownloadURL(f.ProjectID, f.FileID, apiKey)
+		if err != nil {
+			if f.Required {
+				return nil, fmt.Errorf("resolving project %d file %d: %w", f.ProjectID, f.FileID, err)
+			}
+			fmt.Printf("warning: skipping optional mod (project %d file %d): %v\n", f.ProjectID, f.FileID, err)
+			continue
+		}
+		dest, err := safeJoin(filepath.Join(mcdir, "mods"), fileName)
+		if err != nil {
+			return nil, fmt.Errorf("project %d file %d: %w", f.ProjectID, f.FileID, err)
+		}
+		jobs = append(jobs, fetch.Job{
+			URL:   url,
+			Dest:  dest,
+			Label: fileName,
+		})
+	}
+
+	fmt.Printf("Downloading %d mods with %d workers...\n", len(jobs), workers)
+	if err := fetch.RunPool(jobs, workers); err != nil {
+		return nil, fmt.Errorf("downloading mods: %w", err)
+	}
+
+	mcVersion := manifest.Minecraft.Version
+	if mcVersion != "" && installVanilla != nil {
+		if err := installVanilla(mcVersion); err != nil {
+			return nil, fmt.Errorf("installing Minecraft %s: %w", mcVersion, err)
+		}
+	}
+
+	loader := curseForgeModLoader(manifest)
+	profileID := mcVersion
+	if loader != "" {
+		fmt.Printf("warning: modpack needs %s, which mccl cannot install automatically yet; run the upstream installer, then `mccl run -version <profile-id>`\n", loader)
+		profileID = ""
+	}
+
+	return &Result{
+		Name:             manifest.Name,
+		MinecraftVersion: mcVersion,
+		ModLoader:        loader,
+		ProfileVersionID: profileID,
+		FilesInstalled:   len(jobs),
+	}, nil
+}
+
+// curseForgeModLoader returns the primary modloader's id (e.g.
+// "forge-47.2.0"), or "" if the pack is vanilla.
+func curseForgeModLoader(manifest curseForgeManifest) string {
+	for _, l := range manifest.Minecraft.ModLoaders {
+		if l.Primary {
+			return l.Id
+		}
+	}
+	if len(manifest.Minecraft.ModLoaders) > 0 {
+		return manifest.Minecraft.ModLoaders[0].Id
+	}
+	return ""
+}
+
+type curseForgeFileResponse struct {
+	Data struct {
+		FileName    string `json:"fileName"`
+		DownloadURL string `json:"downloadUrl"`
+	} `json:"data"`
+}
+
+// curseForgeDownloadURL resolves a project/file ID pair to a direct download
+// URL via the CurseForge API.
+func curseForgeDownloadURL(projectID, fileID int, apiKey string) (url, fileName string, err error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/mods/%d/files/%d", curseForgeAPIBase, projectID, fileID), nil)
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("x-api-key", apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("curseforge api: http %d", res.StatusCode)
+	}
+
+	var out curseForgeFileResponse
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return "", "", err
+	}
+	if out.Data.DownloadURL == "" {
+		return "", "", fmt.Errorf("curseforge api returned no download URL (the author may have disabled third-party downloads for this mod)")
+	}
+	return out.Data.DownloadURL, out.Data.FileName, nil
+}
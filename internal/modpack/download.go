@@ -0,0 +1,58 @@
+package modpack
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func ensureDir(p string) error {
+	return os.MkdirAll(p, 0o755)
+}
+
+// safeJoin joins name onto destDir and rejects the result if it would land
+// outside destDir - guards against zip-slip entries (e.g.
+// "overrides/../../../../home/user/.bashrc") and equally malicious
+// files[].path / API-reported file names.
+func safeJoin(destDir, name string) (string, error) {
+	target := filepath.Join(destDir, filepath.FromSlash(name))
+	destWithSep := filepath.Clean(destDir) + string(filepath.Separator)
+	if !strings.HasPrefix(target, destWithSep) {
+		return "", fmt.Errorf("%q escapes destination directory %q", name, destDir)
+	}
+	return target, nil
+}
+
+// saveRawVersionJSON writes an already-fetched version JSON (e.g. a fabric
+// or CurseForge-resolved modloader profile) to the same path `mccl install`
+// caches vanilla version JSONs at, so the launch path's inheritsFrom lookup
+// can find it.
+func saveRawVersionJSON(mcdir, id string, v interface{}) error {
+	dir := filepath.Join(mcdir, "versions", id)
+	if err := ensureDir(dir); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, id+".json"), data, 0o644)
+}
+
+func readZipJSON(r *zip.ReadCloser, name string, v interface{}) error {
+	for _, f := range r.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		return json.NewDecoder(rc).Decode(v)
+	}
+	return fmt.Errorf("%s not found in archive", name)
+}
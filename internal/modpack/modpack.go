@@ -0,0 +1,120 @@
+// Package modpack installs a Minecraft instance from a Modrinth .mrpack
+// archive or a CurseForge modpack manifest: it downloads the listed mods,
+// lays the overrides tree on top of the game directory, and triggers the
+// matching vanilla (and, where supported, modloader) install.
+package modpack
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"mccl/internal/fetch"
+)
+
+// Result summarizes what a modpack install produced.
+type Result struct {
+	Name             string
+	MinecraftVersion string
+	ModLoader        string
+	// ProfileVersionID is the version ID `mccl run` should launch, if the
+	// installer was able to produce one: the vanilla MinecraftVersion itself
+	// when the pack needs no modloader, or the modloader's own profile ID
+	// (e.g. Fabric's) when it installed one. Empty when the pack needs a
+	// modloader this installer can't set up, so the caller has no launchable
+	// profile to point the user's run at.
+	ProfileVersionID string
+	FilesInstalled   int
+}
+
+// InstallVanilla installs a vanilla Minecraft version the same way `mccl
+// install` does; the modpack installer calls it once it knows which version
+// the pack targets.
+type InstallVanilla func(versionID string) error
+
+// Install ingests pathOrURL, which may be a local .mrpack/.zip file, a local
+// manifest.json, or an http(s) URL to either, and installs the resulting
+// instance into mcdir.
+func Install(pathOrURL, mcdir string, workers int, curseForgeAPIKey string, installVanilla InstallVanilla) (*Result, error) {
+	localPath, err := resolveLocalPath(pathOrURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasSuffix(strings.ToLower(localPath), ".json") {
+		return InstallCurseForge(localPath, mcdir, workers, curseForgeAPIKey, installVanilla)
+	}
+
+	r, err := zip.OpenReader(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", pathOrURL, err)
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		switch f.Name {
+		case "modrinth.index.json":
+			return InstallMrpack(localPath, mcdir, workers, installVanilla)
+		case "manifest.json":
+			return installCurseForgeZip(r, mcdir, workers, curseForgeAPIKey, installVanilla)
+		}
+	}
+	return nil, fmt.Errorf("%s is neither a .mrpack (modrinth.index.json) nor a CurseForge pack (manifest.json)", pathOrURL)
+}
+
+// resolveLocalPath downloads pathOrURL to a temp file when it's an http(s)
+// URL, otherwise returns it unchanged. A URL whose path ends in .json keeps
+// that suffix on the temp file so Install treats it as a manifest.
+func resolveLocalPath(pathOrURL string) (string, error) {
+	if !strings.HasPrefix(pathOrURL, "http://") && !strings.HasPrefix(pathOrURL, "https://") {
+		return pathOrURL, nil
+	}
+
+	pattern := "mccl-modpack-*"
+	if u, err := url.Parse(pathOrURL); err == nil && strings.HasSuffix(strings.ToLower(u.Path), ".json") {
+		pattern += ".json"
+	}
+	tmp, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	defer tmp.Close()
+
+	res, err := (&http.Client{}).Get(pathOrURL)
+	if err != nil {
+		return "", fmt.Errorf("downloading %s: %w", pathOrURL, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading %s: http %d", pathOrURL, res.StatusCode)
+	}
+
+	if _, err := io.Copy(tmp, res.Body); err != nil {
+		return "", err
+	}
+	return tmp.Name(), nil
+}
+
+func extractOverrides(r *zip.ReadCloser, prefix, mcdir string) error {
+	for _, f := range r.File {
+		if !strings.HasPrefix(f.Name, prefix) || f.FileInfo().IsDir() {
+			continue
+		}
+		rel := strings.TrimPrefix(f.Name, prefix)
+		if rel == "" {
+			continue
+		}
+		target, err := safeJoin(mcdir, rel)
+		if err != nil {
+			return fmt.Errorf("overrides entry %q: %w", f.Name, err)
+		}
+		if err := fetch.ZipEntry(f, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package modpack
+
+import (
+	"archive/zip"
+	"fmt"
+
+	"mccl/internal/fetch"
+)
+
+type mrpackIndex struct {
+	FormatVersion int               `json:"formatVersion"`
+	Game          string            `json:"game"`
+	Name          string            `json:"name"`
+	Files         []mrpackFile      `json:"files"`
+	Dependencies  map[string]string `json:"dependencies"`
+}
+
+type mrpackFile struct {
+	Path      string            `json:"path"`
+	Hashes    map[string]string `json:"hashes"`
+	Env       *mrpackEnv        `json:"env,omitempty"`
+	Downloads []string          `json:"downloads"`
+	FileSize  int               `json:"fileSize"`
+}
+
+type mrpackEnv struct {
+	Client string `json:"client"`
+	Server string `json:"server"`
+}
+
+// fabricMetaProfileURLFormat is the Fabric meta endpoint (formatted with the
+// Minecraft version, then the Fabric loader version) that returns a version
+// JSON (profile) suitable for dropping straight into
+// <mcdir>/versions/<id>/<id>.json and launching via inheritsFrom.
+const fabricMetaProfileURLFormat = "https://meta.fabricmc.net/v2/versions/loader/%s/%s/profile/json"
+
+// InstallMrpack installs a Modrinth .mrpack archive (a zip containing
+// modrinth.index.json, optional overrides/ and client-overrides/ trees) into
+// mcdir.
+func InstallMrpack(archivePath, mcdir string, workers int, installVanilla InstallVanilla) (*Result, error) {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("opening mrpack: %w", err)
+	}
+	defer r.Close()
+
+	var index mrpackIndex
+	if err := readZipJSON(r, "modrinth.index.json", &index); err != nil {
+		return nil, fmt.Errorf("reading modrinth.index.json: %w", err)
+	}
+
+	var jobs []fetch.Job
+	for _, f := range index.Files {
+		if f.Env != nil && f.Env.Client == "unsupported" {
+			continue
+		}
+		if len(f.Downloads) == 0 {
+			continue
+		}
+		dest, err := safeJoin(mcdir, f.Path)
+		if err != nil {
+			return nil, fmt.Errorf("modpack file %q: %w", f.Path, err)
+		}
+		jobs = append(jobs, fetch.Job{
+			URL:   f.Downloads[0],
+			Dest:  dest,
+			Sha1:  f.Hashes["sha1"],
+			Label: f.Path,
+		})
+	}
+
+	fmt.Printf("Downloading %d modpack files with %d workers...\n", len(jobs), workers)
+	if err := fetch.RunPool(jobs, workers); err != nil {
+		return nil, fmt.Errorf("downloading modpack files: %w", err)
+	}
+
+	if err := extractOverrides(r, "overrides/", mcdir); err != nil {
+		return nil, fmt.Errorf("extracting overrides: %w", err)
+	}
+	// client-overrides/ is newer than overrides/ and wins when both exist.
+	if err := extractOverrides(r, "client-overrides/", mcdir); err != nil {
+		return nil, fmt.Errorf("extracting client-overrides: %w", err)
+	}
+
+	mcVersion := index.Dependencies["minecraft"]
+	if mcVersion != "" && installVanilla != nil {
+		if err := installVanilla(mcVersion); err != nil {
+			return nil, fmt.Errorf("installing Minecraft %s: %w", mcVersion, err)
+		}
+	}
+
+	loader, loaderVersion := modLoaderDependency(index.Dependencies)
+	profileID, err := installModLoader(mcdir, mcVersion, loader, loaderVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Name:             index.Name,
+		MinecraftVersion: mcVersion,
+		ModLoader:        loader,
+		ProfileVersionID: profileID,
+		FilesInstalled:   len(jobs),
+	}, nil
+}
+
+func modLoaderDependency(deps map[string]string) (loader, version string) {
+	for _, name := range []string{"fabric-loader", "forge", "quilt-loader", "neoforge"} {
+		if v, ok := deps[name]; ok {
+			return name, v
+		}
+	}
+	return "", ""
+}
+
+// installModLoader lays down the modloader's version profile so the launch
+// path's inheritsFrom merge picks up its libraries and main class, and
+// returns the version ID that profile installed under (so the caller can
+// point `mccl run` at it). Fabric publishes ready-made profiles; Forge/
+// NeoForge/Quilt ship an installer jar instead, which mccl cannot drive yet,
+// so those return no profile ID.
+func installModLoader(mcdir, mcVersion, loader, loaderVersion string) (string, error) {
+	if loader == "" {
+		return mcVersion, nil
+	}
+	if loader != "fabric-loader" {
+		fmt.Printf("warning: modpack needs %s %s, which mccl cannot install automatically yet; run the upstream installer, then `mccl run -version <profile-id>`\n", loader, loaderVersion)
+		return "", nil
+	}
+	if mcVersion == "" {
+		return "", fmt.Errorf("modpack depends on fabric-loader but does not pin a minecraft version")
+	}
+
+	url := fmt.Sprintf(fabricMetaProfileURLFormat, mcVersion, loaderVersion)
+	var profile map[string]interface{}
+	if err := fetch.JSON(url, &profile); err != nil {
+		return "", fmt.Errorf("fetching fabric profile: %w", err)
+	}
+
+	id, _ := profile["id"].(string)
+	if id == "" {
+		return "", fmt.Errorf("fabric profile response had no id")
+	}
+
+	if err := saveRawVersionJSON(mcdir, id, profile); err != nil {
+		return "", err
+	}
+	return id, nil
+}
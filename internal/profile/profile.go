@@ -0,0 +1,51 @@
+// Package profile persists the last-used launch settings alongside the game
+// directory so repeated `mccl run` invocations don't require re-specifying
+// every flag.
+package profile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const fileName = "mccl_profile.json"
+
+type Profile struct {
+	Username  string `json:"username,omitempty"`
+	UUID      string `json:"uuid,omitempty"`
+	MemMaxMB  int    `json:"memMaxMB,omitempty"`
+	MemMinMB  int    `json:"memMinMB,omitempty"`
+	VersionID string `json:"versionId,omitempty"`
+	JavaPath  string `json:"javaPath,omitempty"`
+}
+
+// Load returns the profile stored under mcdir, or a zero-value Profile if
+// none exists yet.
+func Load(mcdir string) (*Profile, error) {
+	data, err := os.ReadFile(filepath.Join(mcdir, fileName))
+	if os.IsNotExist(err) {
+		return &Profile{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var p Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Save writes p under mcdir, creating the directory if needed.
+func Save(mcdir string, p *Profile) error {
+	if err := os.MkdirAll(mcdir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(mcdir, fileName), data, 0o644)
+}